@@ -0,0 +1,262 @@
+package jokes
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Client drives all HTTP calls for a Request: it owns the underlying
+// http.Client, an optional rate limiter shared across goroutines, and
+// an optional response Cache. Request itself stays a plain value type
+// describing what to fetch.
+type Client struct {
+	HTTP    *http.Client
+	Limiter *rate.Limiter
+	Cache   Cache
+	Retry   Retry
+}
+
+// DefaultClient is the Client used by Request.Get and friends.
+var DefaultClient = NewClient()
+
+// Create a new Client with sensible defaults: http.DefaultClient, no
+// rate limiting, no caching, and DefaultRetry.
+func NewClient() *Client {
+	return &Client{
+		HTTP:  http.DefaultClient,
+		Cache: NoopCache{},
+		Retry: DefaultRetry,
+	}
+}
+
+// Get fetches the jokes matching j, honoring ctx cancellation, c.Limiter
+// and c.Cache. If the response is a 429 or a 5xx, the request is
+// retried according to c.Retry before giving up.
+func (c *Client) Get(ctx context.Context, j Request) (r []Joke, e error) {
+	var (
+		cli = c.HTTP
+		key = j.URL()
+		res *http.Response
+		req *http.Request
+		jsn []byte
+	)
+
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	if c.Cache != nil {
+		if cached, ok := c.Cache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	if c.Limiter != nil {
+		if e = c.Limiter.Wait(ctx); e != nil {
+			return
+		}
+	}
+
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := c.Retry.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		if req, e = http.NewRequestWithContext(ctx, http.MethodGet, key, nil); e != nil {
+			return
+		}
+
+		if res, e = cli.Do(req); e != nil {
+			return
+		}
+
+		wait, retryable := retryDelay(res, backoff)
+
+		if !retryable {
+			defer res.Body.Close()
+
+			if jsn, e = ioutil.ReadAll(res.Body); e != nil {
+				return
+			}
+
+			if r, e = ParseResponse(jsn); e != nil {
+				return
+			}
+
+			if c.Cache != nil {
+				c.Cache.Set(key, r)
+			}
+
+			return
+		}
+
+		res.Body.Close()
+
+		if attempt >= attempts {
+			e = fmt.Errorf("jokes: giving up after %d attempts: %s", attempts, res.Status)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			e = ctx.Err()
+			return
+		case <-time.After(withJitter(wait, c.Retry.Jitter)):
+		}
+
+		if backoff *= 2; backoff > c.Retry.MaxBackoff {
+			backoff = c.Retry.MaxBackoff
+		}
+	}
+}
+
+// Retry configures the retry/backoff policy a Client applies when a
+// request hits a rate limit (429) or server error (5xx).
+type Retry struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         float64
+}
+
+// DefaultRetry is the policy used by a Client created with NewClient.
+var DefaultRetry = Retry{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Jitter:         0.2,
+}
+
+// retryDelay inspects res to decide whether it warrants a retry, and
+// if so, how long to wait before the next attempt. A 429 honors the
+// Retry-After header (seconds); a 5xx falls back to backoff.
+func retryDelay(res *http.Response, backoff time.Duration) (time.Duration, bool) {
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		if s := res.Header.Get("Retry-After"); s != "" {
+			if secs, e := strconv.Atoi(s); e == nil {
+				return time.Duration(secs) * time.Second, true
+			}
+		}
+
+		return backoff, true
+	case res.StatusCode >= 500:
+		return backoff, true
+	default:
+		return 0, false
+	}
+}
+
+// withJitter adds up to +/-(jitter*d) of random jitter to d.
+func withJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+
+	delta := float64(d) * jitter * (2*rand.Float64() - 1)
+
+	return d + time.Duration(delta)
+}
+
+// Cache stores the jokes fetched for a Request, keyed by Request.URL().
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]Joke, bool)
+	Set(key string, jokes []Joke)
+}
+
+// NoopCache is a Cache that never stores anything; it's the default
+// for a new Client.
+type NoopCache struct{}
+
+func (NoopCache) Get(key string) ([]Joke, bool) { return nil, false }
+func (NoopCache) Set(key string, jokes []Joke)  {}
+
+// lruEntry is a single cached response.
+type lruEntry struct {
+	key     string
+	jokes   []Joke
+	expires time.Time
+}
+
+// LRUCache is a fixed-capacity, in-memory Cache that evicts the least
+// recently used entry once full, and treats entries older than ttl as
+// misses. A ttl of zero means entries never expire.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache creates an LRUCache holding at most capacity entries,
+// each valid for ttl.
+func NewLRUCache(capacity int, ttl time.Duration) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]Joke, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*lruEntry)
+
+	if c.ttl != 0 && time.Now().After(e.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return e.jokes, true
+}
+
+func (c *LRUCache) Set(key string, jokes []Joke) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).jokes = jokes
+		el.Value.(*lruEntry).expires = time.Now().Add(c.ttl)
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&lruEntry{
+		key:     key,
+		jokes:   jokes,
+		expires: time.Now().Add(c.ttl),
+	})
+	c.items[key] = el
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		if oldest := c.ll.Back(); oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
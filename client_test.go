@@ -0,0 +1,89 @@
+package jokes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRetryDelayTooManyRequests(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"2"}},
+	}
+	d, retryable := retryDelay(res, time.Second)
+	assert.Equal(t, retryable, true)
+	assert.Equal(t, d, 2*time.Second)
+}
+
+func TestRetryDelayServerError(t *testing.T) {
+	res := &http.Response{StatusCode: http.StatusBadGateway}
+	d, retryable := retryDelay(res, 3*time.Second)
+	assert.Equal(t, retryable, true)
+	assert.Equal(t, d, 3*time.Second)
+}
+
+func TestRetryDelayNotRetryable(t *testing.T) {
+	res := &http.Response{StatusCode: http.StatusOK}
+	d, retryable := retryDelay(res, time.Second)
+	assert.Equal(t, retryable, false)
+	assert.Equal(t, d, time.Duration(0))
+}
+
+func TestWithJitterZero(t *testing.T) {
+	assert.Equal(t, withJitter(time.Second, 0), time.Second)
+}
+
+func TestWithJitterBounds(t *testing.T) {
+	d := withJitter(10*time.Second, 0.5)
+	assert.Assert(t, d >= 5*time.Second && d <= 15*time.Second)
+}
+
+func TestNoopCacheAlwaysMisses(t *testing.T) {
+	c := NoopCache{}
+	c.Set("key", []Joke{{Id: 1}})
+
+	_, ok := c.Get("key")
+	assert.Equal(t, ok, false)
+}
+
+func TestLRUCacheGetSet(t *testing.T) {
+	c := NewLRUCache(2, time.Hour)
+	c.Set("a", []Joke{{Id: 1}})
+
+	jokes, ok := c.Get("a")
+	assert.Equal(t, ok, true)
+	assert.DeepEqual(t, jokes, []Joke{{Id: 1}})
+
+	_, ok = c.Get("missing")
+	assert.Equal(t, ok, false)
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUCache(2, time.Hour)
+	c.Set("a", []Joke{{Id: 1}})
+	c.Set("b", []Joke{{Id: 2}})
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	c.Get("a")
+	c.Set("c", []Joke{{Id: 3}})
+
+	_, ok := c.Get("b")
+	assert.Equal(t, ok, false)
+
+	_, ok = c.Get("a")
+	assert.Equal(t, ok, true)
+
+	_, ok = c.Get("c")
+	assert.Equal(t, ok, true)
+}
+
+func TestLRUCacheExpires(t *testing.T) {
+	c := NewLRUCache(10, -time.Second)
+	c.Set("a", []Joke{{Id: 1}})
+
+	_, ok := c.Get("a")
+	assert.Equal(t, ok, false)
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"text/template"
 
 	"github.com/241m/jokes"
 )
@@ -18,20 +21,108 @@ func main() {
 	flag.Func("category", "Add category `cat`", j.Category.Add)
 	flag.Func("lang", "Set languge to `lang`", j.Lang.Set)
 	flag.Func("type", "Set type to `type`", j.Type.Set)
+
+	format := flag.String("format", "text", "Output `format`: text, json, ndjson, or template")
+	tmpl := flag.String("template", "", "`text/template` string used when -format=template")
+	urlOnly := flag.Bool("url", false, "Print the resolved request URL instead of fetching")
+
 	flag.Parse()
 
-	if jokes, e := j.Get(); e != nil {
-		fmt.Println(e)
+	if *urlOnly {
+		fmt.Println(j.URL())
+		return
+	}
+
+	var e error
+
+	switch *format {
+	case "text":
+		e = printText(j)
+	case "json":
+		e = printJSON(j)
+	case "ndjson":
+		e = printNDJSON(j)
+	case "template":
+		e = printTemplate(j, *tmpl)
+	default:
+		e = fmt.Errorf("unknown -format %q", *format)
+	}
+
+	if e != nil {
+		fmt.Fprintln(os.Stderr, e)
 		os.Exit(1)
-	} else {
-		n := len(jokes)
+	}
+}
+
+func printText(j *jokes.Request) error {
+	jks, e := j.Get()
+	if e != nil {
+		return e
+	}
+
+	n := len(jks)
+
+	for i, jk := range jks {
+		fmt.Println(jk)
+
+		if i < n-1 {
+			fmt.Println("---")
+		}
+	}
+
+	return nil
+}
 
-		for i, j := range jokes {
-			fmt.Println(j)
+func printJSON(j *jokes.Request) error {
+	jks, e := j.Get()
+	if e != nil {
+		return e
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(jks)
+}
+
+// printNDJSON streams j using jokes.Stream so callers can pipe amounts
+// larger than the API's per-call cap straight into jq or another tool
+// as the jokes arrive, one JSON object per line.
+func printNDJSON(j *jokes.Request) error {
+	s := j.Stream(context.Background())
+	enc := json.NewEncoder(os.Stdout)
+
+	for s.Next() {
+		if e := enc.Encode(s.Joke()); e != nil {
+			return e
+		}
+	}
+
+	return s.Err()
+}
+
+func printTemplate(j *jokes.Request, text string) error {
+	if text == "" {
+		return fmt.Errorf("-template is required when -format=template")
+	}
+
+	t, e := template.New("joke").Parse(text)
+	if e != nil {
+		return e
+	}
 
-			if i < n-1 {
-				fmt.Println("---")
-			}
+	jks, e := j.Get()
+	if e != nil {
+		return e
+	}
+
+	for _, jk := range jks {
+		if e := t.Execute(os.Stdout, jk); e != nil {
+			return e
 		}
+
+		fmt.Println()
 	}
+
+	return nil
 }
@@ -0,0 +1,51 @@
+package jokes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/241m/jokes"
+	"github.com/241m/jokes/jokestest"
+	"gotest.tools/v3/assert"
+)
+
+func TestRequestGetAgainstFakeServer(t *testing.T) {
+	server := jokestest.NewServer(jokestest.WithCorpus([]jokes.Joke{
+		{Id: 1, Category: jokes.Programming, Setup: "Why?", Delivery: "Because.", Type: jokes.Twopart},
+	}))
+	defer server.Close()
+
+	jokestest.UseFor(t, server)
+
+	jks, e := jokes.Request{Category: jokes.Categories{jokes.Programming}}.Get()
+	assert.NilError(t, e)
+	assert.Equal(t, len(jks), 1)
+	assert.Equal(t, jks[0].Setup, "Why?")
+}
+
+func TestRequestGetRetriesOnRateLimit(t *testing.T) {
+	server := jokestest.NewServer(
+		jokestest.WithForcedError(jokestest.ForcedRateLimit),
+		jokestest.WithRetryAfter(0),
+	)
+	defer server.Close()
+
+	jokestest.UseFor(t, server)
+
+	r := jokes.Request{}
+	c := *jokes.DefaultClient
+	c.Retry = jokes.Retry{MaxAttempts: 2, InitialBackoff: 0, MaxBackoff: 0}
+
+	_, e := c.Get(context.Background(), r)
+	assert.ErrorContains(t, e, "giving up after 2 attempts")
+}
+
+func TestRequestGetNoMatch(t *testing.T) {
+	server := jokestest.NewServer()
+	defer server.Close()
+
+	jokestest.UseFor(t, server)
+
+	_, e := jokes.Request{}.Get()
+	assert.ErrorContains(t, e, "No matching joke found")
+}
@@ -1,10 +1,10 @@
 package jokes
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -79,39 +79,33 @@ func (j Request) URL() string {
 	return url.String()
 }
 
-// Perform the HTTP GET request to fetch the joke by the
-// default http client.
+// Perform the HTTP GET request to fetch the joke using DefaultClient.
 func (j Request) Get() ([]Joke, error) {
-	return j.GetUsingClient(nil)
+	return j.GetWithContext(context.Background())
 }
 
-// Perform the HTTP GET request to fetch the joke by using
-// the given http.Client
-func (j Request) GetUsingClient(client *http.Client) (r []Joke, e error) {
-	var (
-		cli = client
-		url = j.URL()
-		res *http.Response
-		jsn []byte
-	)
-
-	if cli == nil {
-		cli = http.DefaultClient
-	}
+// Perform the HTTP GET request to fetch the joke using DefaultClient,
+// honoring ctx cancellation.
+func (j Request) GetWithContext(ctx context.Context) ([]Joke, error) {
+	return DefaultClient.Get(ctx, j)
+}
 
-	if res, e = cli.Get(url); e != nil {
-		return
-	}
+// Perform the HTTP GET request to fetch the joke by using the given
+// http.Client in place of DefaultClient's.
+func (j Request) GetUsingClient(client *http.Client) ([]Joke, error) {
+	return j.GetUsingClientWithContext(context.Background(), client)
+}
 
-	if jsn, e = ioutil.ReadAll(res.Body); e != nil {
-		return
-	}
+// Perform the HTTP GET request to fetch the joke by using the given
+// http.Client in place of DefaultClient's, honoring ctx cancellation.
+func (j Request) GetUsingClientWithContext(ctx context.Context, client *http.Client) ([]Joke, error) {
+	c := *DefaultClient
 
-	if r, e = ParseResponse(jsn); e != nil {
-		return
+	if client != nil {
+		c.HTTP = client
 	}
 
-	return
+	return c.Get(ctx, j)
 }
 
 // Create a new Request struct.
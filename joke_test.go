@@ -247,5 +247,4 @@ func TestCategoriesAddValid(t *testing.T) {
 }
 
 // TODO: test Response parsing
-// TODO: integration test with mock API
 // TODO: integration test with real API
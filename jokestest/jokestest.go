@@ -0,0 +1,284 @@
+// Package jokestest provides a fake JokeAPI server for exercising
+// jokes.Request against a known, in-memory corpus instead of the
+// real v2.jokeapi.dev.
+package jokestest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/241m/jokes"
+)
+
+// ForcedMode makes a Server respond with a canned failure instead of
+// evaluating the request, for exercising Request's retry and
+// error-handling paths.
+type ForcedMode int
+
+const (
+	// ForcedNone serves requests normally (the default).
+	ForcedNone ForcedMode = iota
+	// ForcedRateLimit always responds 429 with a Retry-After header.
+	ForcedRateLimit
+	// ForcedServerError always responds 500.
+	ForcedServerError
+	// ForcedMalformed responds 200 with a body that isn't valid JSON.
+	ForcedMalformed
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCorpus sets the jokes the fake server filters and serves.
+// Without it, the server serves an empty corpus.
+func WithCorpus(corpus []jokes.Joke) Option {
+	return func(s *Server) { s.corpus = corpus }
+}
+
+// WithForcedError makes every request fail in the given mode.
+func WithForcedError(mode ForcedMode) Option {
+	return func(s *Server) { s.forced = mode }
+}
+
+// WithRetryAfter sets the Retry-After seconds sent by ForcedRateLimit.
+// Defaults to 1.
+func WithRetryAfter(seconds int) Option {
+	return func(s *Server) { s.retryAfter = seconds }
+}
+
+// Server is a fake JokeAPI implementing the /joke/{categories}
+// endpoint against an in-memory corpus.
+type Server struct {
+	*httptest.Server
+
+	corpus     []jokes.Joke
+	forced     ForcedMode
+	retryAfter int
+}
+
+// NewServer starts a fake JokeAPI server configured by opts.
+func NewServer(opts ...Option) *Server {
+	s := &Server{retryAfter: 1}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	switch s.forced {
+	case ForcedRateLimit:
+		w.Header().Set("Retry-After", strconv.Itoa(s.retryAfter))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	case ForcedServerError:
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	case ForcedMalformed:
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{not valid json"))
+		return
+	}
+
+	cats := strings.TrimPrefix(r.URL.Path, "/joke/")
+
+	matches, errResp := s.filter(cats, r.URL.Query())
+	if errResp != nil {
+		writeJoined(w, http.StatusBadRequest, toJSON(errResp), map[string]interface{}{"error": true})
+		return
+	}
+
+	if len(matches) == 1 {
+		writeJoined(w, http.StatusOK, toJSON(matches[0]), map[string]interface{}{"error": false})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"error":  false,
+		"amount": len(matches),
+		"jokes":  matches,
+	})
+}
+
+// filter applies the same query params JokeAPI supports to s.corpus,
+// returning either the matching jokes or an ErrorResponse describing
+// why the request is invalid or unsatisfiable.
+func (s *Server) filter(cats string, q url.Values) ([]jokes.Joke, *jokes.ErrorResponse) {
+	var categories []jokes.Category
+
+	if cats != "" && cats != string(jokes.Any) {
+		for _, c := range strings.Split(cats, ",") {
+			categories = append(categories, jokes.Category(c))
+		}
+	}
+
+	amount := 1
+	if a := q.Get(jokes.KeyAmount); a != "" {
+		n, e := strconv.Atoi(a)
+		if e != nil || n < 1 {
+			return nil, badRequest("invalid amount parameter")
+		}
+		amount = n
+	}
+
+	var blacklist []jokes.Flag
+	if b := q.Get(jokes.KeyBlacklist); b != "" {
+		for _, f := range strings.Split(b, ",") {
+			blacklist = append(blacklist, jokes.Flag(f))
+		}
+	}
+
+	contains := strings.ToLower(q.Get(jokes.KeyContains))
+
+	var lower, upper int
+	if r := q.Get(jokes.KeyIDRange); r != "" {
+		parts := strings.SplitN(r, "-", 2)
+
+		var e error
+
+		if lower, e = strconv.Atoi(parts[0]); e != nil {
+			return nil, badRequest("invalid idRange parameter")
+		}
+
+		if len(parts) == 2 {
+			if upper, e = strconv.Atoi(parts[1]); e != nil {
+				return nil, badRequest("invalid idRange parameter")
+			}
+		}
+	}
+
+	lang := jokes.Lang(q.Get(jokes.KeyLang))
+	_, safe := q[jokes.KeySafe]
+	typ := jokes.Type(q.Get(jokes.KeyType))
+
+	var matches []jokes.Joke
+
+	for _, j := range s.corpus {
+		if len(categories) > 0 && !containsCategory(categories, j.Category) {
+			continue
+		}
+
+		if containsAnyFlag(blacklist, j.Flags) {
+			continue
+		}
+
+		if contains != "" &&
+			!strings.Contains(strings.ToLower(j.Setup), contains) &&
+			!strings.Contains(strings.ToLower(j.Delivery), contains) &&
+			!strings.Contains(strings.ToLower(j.Joke), contains) {
+			continue
+		}
+
+		if upper > 0 && (j.Id < lower || j.Id > upper) {
+			continue
+		} else if upper == 0 && lower > 0 && j.Id != lower {
+			continue
+		}
+
+		if lang != "" && j.Lang != lang {
+			continue
+		}
+
+		if safe && !j.Safe {
+			continue
+		}
+
+		if typ != "" && j.Type != typ {
+			continue
+		}
+
+		matches = append(matches, j)
+
+		if len(matches) == amount {
+			break
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, &jokes.ErrorResponse{
+			Code:    106,
+			Message: "No matching joke found",
+			Info:    "No matching joke was found in the fake corpus",
+		}
+	}
+
+	return matches, nil
+}
+
+func containsCategory(categories []jokes.Category, c jokes.Category) bool {
+	for _, want := range categories {
+		if want == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAnyFlag(blacklist []jokes.Flag, flags map[jokes.Flag]bool) bool {
+	for _, f := range blacklist {
+		if flags[f] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func badRequest(msg string) *jokes.ErrorResponse {
+	return &jokes.ErrorResponse{Code: 1, Message: msg}
+}
+
+// toJSON round-trips v through JSON to get a map, so extra top-level
+// keys (like "error") can be merged in before re-encoding.
+func toJSON(v interface{}) map[string]interface{} {
+	b, _ := json.Marshal(v)
+
+	m := map[string]interface{}{}
+	json.Unmarshal(b, &m)
+
+	return m
+}
+
+func writeJoined(w http.ResponseWriter, code int, maps ...map[string]interface{}) {
+	joined := map[string]interface{}{}
+
+	for _, m := range maps {
+		for k, v := range m {
+			joined[k] = v
+		}
+	}
+
+	writeJSON(w, code, joined)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// UseFor points jokes.BaseURL at s for the duration of the test,
+// restoring the original value on cleanup.
+func UseFor(t *testing.T, s *Server) {
+	t.Helper()
+
+	u, e := url.Parse(s.URL)
+	if e != nil {
+		t.Fatalf("jokestest: parse server URL: %v", e)
+	}
+
+	orig := jokes.BaseURL
+	jokes.BaseURL = *u
+
+	t.Cleanup(func() { jokes.BaseURL = orig })
+}
@@ -0,0 +1,173 @@
+package jokes
+
+import (
+	"context"
+	"errors"
+)
+
+// Stream pages through a Request's results, transparently issuing
+// successive requests since JokeAPI caps "amount" at 10 per call.
+// Use it like a bufio.Scanner:
+//
+//	s := req.Stream(ctx)
+//	for s.Next() {
+//		fmt.Println(s.Joke())
+//	}
+//	if e := s.Err(); e != nil {
+//		...
+//	}
+type Stream struct {
+	ctx    context.Context
+	client *Client
+	req    Request
+
+	seen      map[int]bool
+	remaining int // -1 means infinite
+
+	buf []Joke
+	idx int
+	cur Joke
+
+	staleFetches int
+	err          error
+	done         bool
+}
+
+// maxPageSize is the largest "amount" JokeAPI accepts per call.
+const maxPageSize = 10
+
+// maxStaleFetches bounds how many consecutive all-duplicate pages a
+// Stream tolerates before giving up, so a small corpus can't spin it
+// forever.
+const maxStaleFetches = 3
+
+// Stream pages through j using DefaultClient. An Amount of 0 means
+// "infinite until ctx is canceled".
+func (j Request) Stream(ctx context.Context) *Stream {
+	return DefaultClient.Stream(ctx, j)
+}
+
+// Stream pages through j using c, tracking seen joke IDs to skip
+// duplicates across pages and respecting c.Limiter. An Amount of 0
+// means "infinite until ctx is canceled".
+func (c *Client) Stream(ctx context.Context, j Request) *Stream {
+	remaining := j.Amount
+	if remaining == 0 {
+		remaining = -1
+	}
+
+	return &Stream{
+		ctx:       ctx,
+		client:    c,
+		req:       j,
+		seen:      map[int]bool{},
+		remaining: remaining,
+	}
+}
+
+// Next advances the Stream to the next Joke, fetching further pages
+// as needed. It returns false once the requested Amount has been
+// reached, the underlying API runs out of jokes, ctx is canceled, or
+// a request fails; check Err to distinguish the latter two.
+func (s *Stream) Next() bool {
+	for {
+		if s.done || s.err != nil {
+			return false
+		}
+
+		if s.idx < len(s.buf) {
+			s.cur = s.buf[s.idx]
+			s.idx++
+			return true
+		}
+
+		if s.remaining == 0 {
+			s.done = true
+			return false
+		}
+
+		if e := s.ctx.Err(); e != nil {
+			if e != context.Canceled {
+				s.err = e
+			}
+
+			s.done = true
+			return false
+		}
+
+		if !s.fetch() {
+			return false
+		}
+	}
+}
+
+// fetch issues the next page and appends its unseen jokes to s.buf.
+func (s *Stream) fetch() bool {
+	page := maxPageSize
+	if s.remaining > 0 && s.remaining < page {
+		page = s.remaining
+	}
+
+	req := s.req
+	req.Amount = page
+
+	jokes, e := s.client.Get(s.ctx, req)
+	if e != nil {
+		if !errors.Is(e, context.Canceled) {
+			s.err = e
+		}
+
+		s.done = true
+		return false
+	}
+
+	if len(jokes) == 0 {
+		s.done = true
+		return false
+	}
+
+	s.buf = s.buf[:0]
+	s.idx = 0
+
+	for _, jk := range jokes {
+		if s.remaining == 0 {
+			break
+		}
+
+		if s.seen[jk.Id] {
+			continue
+		}
+
+		s.seen[jk.Id] = true
+		s.buf = append(s.buf, jk)
+
+		if s.remaining > 0 {
+			s.remaining--
+		}
+	}
+
+	if len(s.buf) == 0 {
+		s.staleFetches++
+
+		if s.staleFetches >= maxStaleFetches {
+			s.done = true
+			return false
+		}
+	} else {
+		s.staleFetches = 0
+	}
+
+	return true
+}
+
+// Joke returns the Joke at the Stream's current position. Call it
+// only after a call to Next returns true.
+func (s *Stream) Joke() Joke {
+	return s.cur
+}
+
+// Err returns the first error encountered while paging, if any. A
+// canceled ctx is reported by ending the Stream, not as an Err.
+func (s *Stream) Err() error {
+	return s.err
+}
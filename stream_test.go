@@ -0,0 +1,112 @@
+package jokes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// pagingTransport serves successive pages of jokes from a fixed set,
+// ignoring the actual request URL, to exercise Stream without a real
+// HTTP server.
+type pagingTransport struct {
+	pages [][]Joke
+	calls int
+}
+
+func (t *pagingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var jokes []Joke
+
+	if t.calls < len(t.pages) {
+		jokes = t.pages[t.calls]
+	}
+
+	t.calls++
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"error":  false,
+		"amount": len(jokes),
+		"jokes":  jokes,
+	})
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Header:     http.Header{},
+	}, nil
+}
+
+func newStreamClient(pages [][]Joke) *Client {
+	c := NewClient()
+	c.HTTP = &http.Client{Transport: &pagingTransport{pages: pages}}
+	return c
+}
+
+func TestStreamPagesAndDedupes(t *testing.T) {
+	c := newStreamClient([][]Joke{
+		{{Id: 1}, {Id: 2}},
+		{{Id: 2}, {Id: 3}},
+		{},
+	})
+
+	s := c.Stream(context.Background(), Request{Amount: 10})
+
+	var ids []int
+	for s.Next() {
+		ids = append(ids, s.Joke().Id)
+	}
+
+	assert.NilError(t, s.Err())
+	assert.DeepEqual(t, ids, []int{1, 2, 3})
+}
+
+func TestStreamRespectsAmount(t *testing.T) {
+	c := newStreamClient([][]Joke{
+		{{Id: 1}, {Id: 2}, {Id: 3}, {Id: 4}, {Id: 5}},
+	})
+
+	s := c.Stream(context.Background(), Request{Amount: 3})
+
+	var ids []int
+	for s.Next() {
+		ids = append(ids, s.Joke().Id)
+	}
+
+	assert.NilError(t, s.Err())
+	assert.DeepEqual(t, ids, []int{1, 2, 3})
+}
+
+func TestStreamGivesUpOnRepeatedDuplicates(t *testing.T) {
+	c := newStreamClient([][]Joke{
+		{{Id: 1}},
+		{{Id: 1}},
+		{{Id: 1}},
+		{{Id: 1}},
+	})
+
+	s := c.Stream(context.Background(), Request{Amount: 0})
+
+	var ids []int
+	for s.Next() {
+		ids = append(ids, s.Joke().Id)
+	}
+
+	assert.NilError(t, s.Err())
+	assert.DeepEqual(t, ids, []int{1})
+}
+
+func TestStreamStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := newStreamClient([][]Joke{{{Id: 1}}})
+	s := c.Stream(ctx, Request{Amount: 0})
+
+	assert.Equal(t, s.Next(), false)
+	assert.NilError(t, s.Err())
+}